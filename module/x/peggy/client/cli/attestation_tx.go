@@ -17,7 +17,6 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
-	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +35,8 @@ func GetObservedCmd(cdc *codec.Codec) *cobra.Command {
 		CmdSendETHDepositRequest(cdc),
 		CmdSendETHWithdrawalRequest(cdc),
 		CmdSendETHMultiSigRequest(cdc),
+		CmdSendETHMigrationCompletedRequest(cdc),
+		CmdObservedFromFile(cdc),
 	)...)
 
 	return testingTxCmd
@@ -238,19 +239,56 @@ func CmdSendETHMultiSigRequest(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
-func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
+func CmdSendETHMigrationCompletedRequest(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "valset-confirm [nonce] [eth private key]",
+		Use:   "migration-completed [eth chain id] [eth contract address] [nonce] [new safe address]",
+		Short: "Submit a claim that the new bridge contract received its migrated custody on the Ethereum side",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cosmosAddr := cliCtx.GetFromAddress()
+
+			ethChainID := args[0]
+			ethContractAddress := args[1]
+			nonce, err := parseNonce(args[2])
+			if err != nil {
+				return err
+			}
+			newSafe := types.NewEthereumAddress(args[3])
+
+			msg := types.MsgCreateEthereumClaims{
+				EthereumChainID:       ethChainID,
+				BridgeContractAddress: types.NewEthereumAddress(ethContractAddress),
+				Orchestrator:          cosmosAddr,
+				Claims: []types.EthereumClaim{
+					types.EthereumBridgeMigrationCompletedClaim{
+						Nonce:   nonce,
+						NewSafe: newSafe,
+					},
+				},
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "valset-confirm [nonce]",
 		Short: "Sign a `multisig set` update for given nonce with the Ethereum key and submit to cosmos side",
-		Args:  cobra.ExactArgs(2),
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
 			// Make Eth Signature over valset
-			privKeyString := args[1][2:]
-			privateKey, err := ethCrypto.HexToECDSA(privKeyString)
+			privateKey, err := getEthPrivateKey(cmd)
 			if err != nil {
 				return err
 			}
@@ -266,9 +304,8 @@ func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 			var valset types.Valset
 			cdc.MustUnmarshalJSON(res, &valset)
-			checkpoint := valset.GetCheckpoint()
 
-			signature, err := types.NewEthereumSignature(checkpoint, privateKey)
+			signature, err := signValsetCheckpoint(cmd, storeKey, cliCtx, valset, privateKey)
 			if err != nil {
 				return err
 			}
@@ -288,21 +325,23 @@ func CmdValsetConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	addEthKeySigningFlags(cmd)
+	addSigningModeFlags(cmd)
+	return cmd
 }
 
 func CmdOutgointTXBatchConfirm(storeKey string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "batch-confirm [nonce] [eth private key]",
+	cmd := &cobra.Command{
+		Use:   "batch-confirm [nonce]",
 		Short: "Sign a `outgoing TX` batch for given nonce with the Ethereum key and submit to cosmos side",
-		Args:  cobra.ExactArgs(2),
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
 			// Make Eth Signature over valset
-			privKeyString := args[1][2:]
-			privateKey, err := ethCrypto.HexToECDSA(privKeyString)
+			privateKey, err := getEthPrivateKey(cmd)
 			if err != nil {
 				return err
 			}
@@ -334,7 +373,7 @@ func CmdOutgointTXBatchConfirm(storeKey string, cdc *codec.Codec) *cobra.Command
 				return err
 			}
 
-			signature, err := types.NewEthereumSignature(checkpoint, privateKey)
+			signature, err := signBatchCheckpoint(cmd, storeKey, cliCtx, batch, checkpoint, privateKey)
 			if err != nil {
 				return err
 			}
@@ -353,6 +392,9 @@ func CmdOutgointTXBatchConfirm(storeKey string, cdc *codec.Codec) *cobra.Command
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	addEthKeySigningFlags(cmd)
+	addSigningModeFlags(cmd)
+	return cmd
 }
 
 func parseNonce(nonceArg string) (types.UInt64Nonce, error) {
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	clictx "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	ethereum "github.com/ethereum/go-ethereum"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/althea-net/peggy/module/x/peggy/keeper"
+)
+
+const (
+	flagRelayerAddress = "relayer"
+	flagBatchPrice     = "price"
+
+	// gasFloor/gasCap bound the binary search used in place of a node's
+	// eth_estimateGas, which is unreliable for a contract call this
+	// complex, the same doCall pattern Ethermint's eth_estimateGas uses.
+	gasFloor uint64 = 21000
+	gasCap   uint64 = 10000000
+)
+
+// relayCostResult is the shape printed by `batch-relay-cost`.
+type relayCostResult struct {
+	GasEstimate              uint64  `json:"gasEstimate"`
+	SuggestedGasPriceGwei    float64 `json:"suggestedGasPriceGwei"`
+	EstimatedFeeWei          string  `json:"estimatedFeeWei"`
+	EstimatedFeeInBatchToken string  `json:"estimatedFeeInBatchToken"`
+}
+
+// CmdBatchRelayCost estimates the Ethereum gas cost of relaying a stored
+// outgoing tx batch, so an orchestrator can judge whether the batch's
+// aggregated fees actually cover the relay before signing it.
+func CmdBatchRelayCost(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-relay-cost [nonce]",
+		Short: "estimate the Ethereum gas cost of relaying a stored outgoing tx batch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := clictx.NewCLIContext().WithCodec(cdc)
+
+			nonce := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/batch/%s/relayCost", storeKey, nonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+
+			var quote keeper.RelayCostQueryResponse
+			cdc.MustUnmarshalJSON(res, &quote)
+
+			ethRPC := viper.GetString(flagEthRPC)
+			if ethRPC == "" {
+				return fmt.Errorf("--%s is required to estimate gas against a live Ethereum node", flagEthRPC)
+			}
+
+			client, err := ethclient.Dial(ethRPC)
+			if err != nil {
+				return fmt.Errorf("dialing eth RPC: %w", err)
+			}
+			defer client.Close()
+
+			relayerFlag := viper.GetString(flagRelayerAddress)
+			if relayerFlag == "" {
+				return fmt.Errorf("--%s is required: gas estimation needs a real sender, not the zero address", flagRelayerAddress)
+			}
+			relayer := ethCommon.HexToAddress(relayerFlag)
+			bridgeContract := ethCommon.HexToAddress(quote.BridgeContractAddress.String())
+			callMsg := ethereum.CallMsg{
+				From: relayer,
+				To:   &bridgeContract,
+				Data: quote.Calldata,
+			}
+
+			gasEstimate, err := estimateGasBinarySearch(context.Background(), client, callMsg)
+			if err != nil {
+				return fmt.Errorf("estimating gas: %w", err)
+			}
+
+			gasPrice, err := client.SuggestGasPrice(context.Background())
+			if err != nil {
+				return fmt.Errorf("fetching suggested gas price: %w", err)
+			}
+
+			feeWei := new(big.Int).Mul(new(big.Int).SetUint64(gasEstimate), gasPrice)
+
+			price := quote.OraclePriceWei
+			if priceFlag := viper.GetString(flagBatchPrice); priceFlag != "" {
+				parsed, ok := new(big.Int).SetString(priceFlag, 10)
+				if !ok {
+					return fmt.Errorf("invalid --%s, must be an integer wei-per-token price", flagBatchPrice)
+				}
+				price = parsed
+			}
+
+			result := relayCostResult{
+				GasEstimate:           gasEstimate,
+				SuggestedGasPriceGwei: weiToGwei(gasPrice),
+				EstimatedFeeWei:       feeWei.String(),
+			}
+			if price != nil && price.Sign() > 0 {
+				result.EstimatedFeeInBatchToken = new(big.Int).Div(feeWei, price).String()
+			}
+
+			out, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagEthRPC, "", "eth RPC endpoint to estimate gas against")
+	cmd.Flags().String(flagRelayerAddress, "", "Ethereum address the relay tx would be sent from (required)")
+	cmd.Flags().String(flagBatchPrice, "", "wei-per-token price to use instead of the module's tracked oracle price")
+	return cmd
+}
+
+// estimateGasBinarySearch finds the smallest gas limit between gasFloor and
+// gasCap for which an eth_call against callMsg succeeds, the same doCall
+// binary search Ethermint's eth_estimateGas implementation uses for calls a
+// plain eth_estimateGas handles poorly.
+func estimateGasBinarySearch(ctx context.Context, client *ethclient.Client, callMsg ethereum.CallMsg) (uint64, error) {
+	lo, hi := gasFloor, gasCap
+
+	doCall := func(gas uint64) error {
+		callMsg.Gas = gas
+		_, err := client.CallContract(ctx, callMsg, nil)
+		return err
+	}
+
+	if err := doCall(hi); err != nil {
+		return 0, fmt.Errorf("call fails even at the gas cap (%d): %w", hi, err)
+	}
+
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		if doCall(mid) == nil {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// weiToGwei converts a wei big.Int gas price into a float64 gwei value for
+// display.
+func weiToGwei(wei *big.Int) float64 {
+	gwei := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9))
+	f, _ := gwei.Float64()
+	return f
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/althea-net/peggy/module/x/peggy/keeper"
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethCore "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagEthNonce       = "eth-nonce"
+	flagGasPrice       = "gas-price"
+	flagEthChainID     = "eth-chain-id"
+	flagOutFile        = "out"
+	flagEIP1559        = "eip1559"
+	flagMaxFeePerGas   = "max-fee-per-gas"
+	flagMaxPriorityFee = "max-priority-fee-per-gas"
+)
+
+// GetEthRelayCmd returns the top-level commands for relaying a confirmed
+// batch to Ethereum without a Go relayer daemon: build-eth-relay signs and
+// emits the raw tx, decode-eth-relay is its inverse for cross-checking one.
+// These sit alongside the `observed` and `approved` command groups under
+// `tx peggy`.
+func GetEthRelayCmd(storeKey string, cdc *codec.Codec) []*cobra.Command {
+	return flags.PostCommands(
+		CmdBuildEthRelayTx(storeKey, cdc),
+		CmdDecodeEthRelayTx(storeKey, cdc),
+	)
+}
+
+// CmdBuildEthRelayTx pulls a confirmed batch and its collected validator
+// signatures from cosmos state, ABI-encodes a submitBatch call to the
+// bridge contract, signs it with the operator's Ethereum key, and prints
+// the RLP-encoded raw transaction so it can be relayed with any Ethereum
+// provider without embedding a Go relayer daemon.
+func CmdBuildEthRelayTx(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build-eth-relay [nonce]",
+		Short: "build, sign, and print a raw Ethereum submitBatch transaction for a confirmed batch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			nonce := args[0]
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/lastObservedMultiSigUpdate", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+			var updateRsp keeper.MultiSigUpdateResponse
+			cdc.MustUnmarshalJSON(res, &updateRsp)
+
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/batch/%s", storeKey, nonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+			var batch types.OutgoingTxBatch
+			cdc.MustUnmarshalJSON(res, &batch)
+
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/batch/%s/confirmations", storeKey, nonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+			var confirmations []types.MsgBridgeSignatureSubmission
+			cdc.MustUnmarshalJSON(res, &confirmations)
+
+			calldata, err := keeper.EncodeSubmitBatchCalldata(updateRsp.Valset, confirmations, batch)
+			if err != nil {
+				return fmt.Errorf("encoding submitBatch calldata: %w", err)
+			}
+
+			privateKey, err := getEthPrivateKey(cmd)
+			if err != nil {
+				return err
+			}
+
+			ethChainID := new(big.Int).SetUint64(viper.GetUint64(flagEthChainID))
+			bridgeContractFlag := viper.GetString(flagEthContractAddress)
+			if bridgeContractFlag == "" {
+				return fmt.Errorf("--%s is required", flagEthContractAddress)
+			}
+			bridgeContract := ethCommon.HexToAddress(bridgeContractFlag)
+
+			var tx *ethCore.Transaction
+			if viper.GetBool(flagEIP1559) {
+				tx = ethCore.NewTx(&ethCore.DynamicFeeTx{
+					ChainID:   ethChainID,
+					Nonce:     viper.GetUint64(flagEthNonce),
+					GasTipCap: gweiToWei(viper.GetFloat64(flagMaxPriorityFee)),
+					GasFeeCap: gweiToWei(viper.GetFloat64(flagMaxFeePerGas)),
+					Gas:       viper.GetUint64("gas"),
+					To:        &bridgeContract,
+					Data:      calldata,
+				})
+			} else {
+				tx = ethCore.NewTx(&ethCore.LegacyTx{
+					Nonce:    viper.GetUint64(flagEthNonce),
+					GasPrice: gweiToWei(viper.GetFloat64(flagGasPrice)),
+					Gas:      viper.GetUint64("gas"),
+					To:       &bridgeContract,
+					Data:     calldata,
+				})
+			}
+
+			signer := ethCore.NewLondonSigner(ethChainID)
+			signedTx, err := ethCore.SignTx(tx, signer, privateKey)
+			if err != nil {
+				return fmt.Errorf("signing eth tx: %w", err)
+			}
+
+			// MarshalBinary yields the canonical raw form: plain RLP for a
+			// legacy tx, the EIP-2718 typed envelope for an EIP-1559 one.
+			rawTx, err := signedTx.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("encoding signed tx: %w", err)
+			}
+			rawHex := fmt.Sprintf("0x%x", rawTx)
+
+			if out := viper.GetString(flagOutFile); out != "" {
+				return ioutil.WriteFile(out, []byte(rawHex+"\n"), 0644)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), rawHex)
+			return nil
+		},
+	}
+	addEthKeySigningFlags(cmd)
+	cmd.Flags().Uint64(flagEthNonce, 0, "Ethereum account nonce for the relay tx")
+	cmd.Flags().Float64(flagGasPrice, 0, "legacy gas price in gwei")
+	cmd.Flags().Float64(flagMaxFeePerGas, 0, "EIP-1559 max fee per gas in gwei")
+	cmd.Flags().Float64(flagMaxPriorityFee, 0, "EIP-1559 max priority fee per gas in gwei")
+	cmd.Flags().Bool(flagEIP1559, false, "build an EIP-1559 dynamic fee tx instead of a legacy tx")
+	cmd.Flags().Uint64(flagEthChainID, 1, "Ethereum chain id to sign for")
+	cmd.Flags().Uint64("gas", 0, "gas limit for the relay tx")
+	cmd.Flags().String(flagOutFile, "", "write the raw RLP-encoded tx hex to this file instead of stdout")
+	cmd.Flags().String(flagEthContractAddress, "", "bridge contract address to submit the batch to")
+	return cmd
+}
+
+// CmdDecodeEthRelayTx parses a raw Ethereum transaction produced by
+// build-eth-relay and checks the batch nonce and valset nonce in its
+// calldata against the batch and valset currently stored on the cosmos
+// side. The signatures, transfers and token contract in the calldata are
+// not compared.
+func CmdDecodeEthRelayTx(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "decode-eth-relay [raw hex]",
+		Short: "decode a raw submitBatch transaction and cross-check it against cosmos state",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			rawHex := strings.TrimPrefix(args[0], "0x")
+			rawBytes := ethCommon.FromHex(rawHex)
+
+			var tx ethCore.Transaction
+			if err := tx.UnmarshalBinary(rawBytes); err != nil {
+				return fmt.Errorf("decoding raw tx: %w", err)
+			}
+
+			batchNonce, valsetNonce, err := keeper.DecodeSubmitBatchCalldata(tx.Data())
+			if err != nil {
+				return fmt.Errorf("decoding submitBatch calldata: %w", err)
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/batch/%d", storeKey, batchNonce), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+			var batch types.OutgoingTxBatch
+			cdc.MustUnmarshalJSON(res, &batch)
+
+			res, _, err = cliCtx.QueryWithData(fmt.Sprintf("custom/%s/lastObservedMultiSigUpdate", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+			var updateRsp keeper.MultiSigUpdateResponse
+			cdc.MustUnmarshalJSON(res, &updateRsp)
+
+			fmt.Fprintf(cmd.OutOrStdout(), "to: %s\n", tx.To().String())
+			fmt.Fprintf(cmd.OutOrStdout(), "nonce: %d\n", tx.Nonce())
+			fmt.Fprintf(cmd.OutOrStdout(), "decoded batch nonce: %d (cosmos batch nonce: %s)\n", batchNonce, batch.Nonce)
+			fmt.Fprintf(cmd.OutOrStdout(), "decoded valset nonce: %d (cosmos valset nonce: %s)\n", valsetNonce, updateRsp.Valset.Nonce)
+			if uint64(batch.Nonce) != batchNonce {
+				return fmt.Errorf("calldata batch nonce %d does not match cosmos batch nonce %s", batchNonce, batch.Nonce)
+			}
+			if uint64(updateRsp.Valset.Nonce) != valsetNonce {
+				return fmt.Errorf("calldata valset nonce %d does not match cosmos valset nonce %s", valsetNonce, updateRsp.Valset.Nonce)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "OK: calldata batch and valset nonces match the cosmos-side batch and valset")
+			return nil
+		},
+	}
+}
+
+// gweiToWei converts a gwei float64 flag value into a wei big.Int, rounding
+// down, for use as a legacy gas price or EIP-1559 fee cap.
+func gweiToWei(gwei float64) *big.Int {
+	wei := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9))
+	result, _ := wei.Int(nil)
+	return result
+}
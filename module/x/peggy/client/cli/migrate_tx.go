@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/althea-net/peggy/module/x/peggy/keeper"
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	clictx "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	ethereum "github.com/ethereum/go-ethereum"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagEthRPC  = "eth-rpc"
+	flagEthSafe = "eth-safe"
+
+	// erc20BalanceOfSelector is the 4-byte selector for the ERC20
+	// balanceOf(address) call, keccak256("balanceOf(address)")[:4].
+	erc20BalanceOfSelector = "70a08231"
+)
+
+// GetMigrateCmd returns the `migrate` command group used to move custody of
+// every ERC20 held by the current bridge contract to a newly deployed one.
+func GetMigrateCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:                        "migrate",
+		Short:                      "migrate bridge contract custody to a newly deployed Ethereum contract",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	migrateCmd.AddCommand(flags.PostCommands(
+		CmdMigratePlan(storeKey, cdc),
+		CmdMigrateCreateBatch(storeKey, cdc),
+	)...)
+
+	return migrateCmd
+}
+
+// CmdMigratePlan queries the keeper's custody-inventory for every tracked
+// ERC20 and emits a JSON manifest of tokens and balances to migrate, so an
+// operator can review it before any on-chain msg is broadcast.
+func CmdMigratePlan(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "emit a JSON manifest of the ERC20 balances the bridge contract currently custodies",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := clictx.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/custodyInventory", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+
+			var inventory []keeper.CustodyBalance
+			cdc.MustUnmarshalJSON(res, &inventory)
+
+			if ethRPC := viper.GetString(flagEthRPC); ethRPC != "" {
+				safe := viper.GetString(flagEthSafe)
+				if safe == "" {
+					return fmt.Errorf("--%s is required together with --%s to cross-check custody via balanceOf", flagEthSafe, flagEthRPC)
+				}
+				if err := crossCheckCustodyInventory(ethRPC, safe, inventory); err != nil {
+					return fmt.Errorf("cross-checking custody inventory against eth node: %w", err)
+				}
+			}
+
+			manifest, err := json.MarshalIndent(inventory, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(manifest))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagEthRPC, "", "eth RPC endpoint to cross-check the inventory against a live balanceOf call")
+	cmd.Flags().String(flagEthSafe, "", "bridge contract (safe) address whose balanceOf is cross-checked against the keeper's inventory")
+	return cmd
+}
+
+// crossCheckCustodyInventory calls balanceOf(safe) on every tracked token's
+// contract against a live Ethereum node and returns an error naming every
+// token whose on-chain balance disagrees with the keeper's view of custody,
+// rather than silently reporting success when the check can't be made.
+func crossCheckCustodyInventory(ethRPC, safe string, inventory []keeper.CustodyBalance) error {
+	client, err := ethclient.Dial(ethRPC)
+	if err != nil {
+		return fmt.Errorf("dialing eth RPC: %w", err)
+	}
+	defer client.Close()
+
+	safeAddr := ethCommon.HexToAddress(safe)
+	var mismatches []string
+	for _, item := range inventory {
+		tokenAddr := ethCommon.HexToAddress(item.TokenContract.String())
+		calldata := ethCommon.Hex2Bytes(erc20BalanceOfSelector)
+		calldata = append(calldata, ethCommon.LeftPadBytes(safeAddr.Bytes(), 32)...)
+
+		callMsg := ethereum.CallMsg{To: &tokenAddr, Data: calldata}
+		result, err := client.CallContract(context.Background(), callMsg, nil)
+		if err != nil {
+			return fmt.Errorf("balanceOf(%s) on %s: %w", safe, item.TokenContract.String(), err)
+		}
+
+		onChainBalance := new(big.Int).SetBytes(result)
+		if onChainBalance.Cmp(item.Balance.BigInt()) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s (%s): keeper=%s eth=%s", item.Symbol, item.TokenContract.String(), item.Balance.String(), onChainBalance.String()))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("custody inventory disagrees with on-chain balanceOf for: %v", mismatches)
+	}
+	return nil
+}
+
+// CmdMigrateCreateBatch builds one MsgCreateMigrationBatch listing every
+// token in the custody inventory, each moving the full balance from the old bridge
+// contract (the safe) to the newly deployed one. The resulting batches are
+// signed by validators through the existing batch-confirm flow.
+func CmdMigrateCreateBatch(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-batch [new safe address]",
+		Short: "create an outgoing tx batch per token moving its full balance to the new bridge contract",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := clictx.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cosmosAddr := cliCtx.GetFromAddress()
+
+			newSafe := types.NewEthereumAddress(args[0])
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/custodyInventory", storeKey), nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return ErrNotFound
+			}
+
+			var inventory []keeper.CustodyBalance
+			cdc.MustUnmarshalJSON(res, &inventory)
+
+			msg := types.MsgCreateMigrationBatch{
+				Orchestrator: cosmosAddr,
+				NewSafe:      newSafe,
+			}
+			for _, item := range inventory {
+				// NewERC20Token takes a uint64 amount, which would truncate
+				// a full custody balance for any 18-decimal ERC20 over ~18
+				// tokens; build the token directly so item.Balance keeps its
+				// full sdk.Int precision all the way into the batch.
+				msg.Tokens = append(msg.Tokens, types.ERC20Token{
+					Amount:   item.Balance,
+					Symbol:   item.Symbol,
+					Contract: item.TokenContract,
+				})
+			}
+
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagFromBlock = "from-block"
+	flagToBlock   = "to-block"
+)
+
+// rawEventManifest is the JSON shape accepted by `observed from-file`: one
+// eth chain id/bridge contract pair and a list of heterogeneous claims.
+type rawEventManifest struct {
+	EthereumChainID       string            `json:"eth_chain_id"`
+	BridgeContractAddress string            `json:"bridge_contract_address"`
+	Events                []json.RawMessage `json:"events"`
+}
+
+// rawEvent carries just enough to dispatch on `type` before unmarshalling
+// into the concrete claim.
+type rawEvent struct {
+	Type string `json:"type"`
+}
+
+// CmdObservedFromFile batches a range of previously-missed Ethereum events
+// into a single MsgCreateEthereumClaims, so an orchestrator replaying a
+// range of blocks after downtime pays one cosmos tx and one signature
+// instead of one per event.
+func CmdObservedFromFile(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "from-file [events.json]",
+		Short: "submit a batch of observed ETH events from a hand-built JSON manifest as a single tx",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ethRPC := viper.GetString(flagEthRPC); ethRPC != "" {
+				manifest, err := scanEthLogsManifest(ethRPC, viper.GetUint64(flagFromBlock), viper.GetUint64(flagToBlock))
+				if err != nil {
+					return fmt.Errorf("scanning eth logs: %w", err)
+				}
+				out, err := json.MarshalIndent(manifest, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(args[0], out, 0644); err != nil {
+					return fmt.Errorf("writing manifest: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "wrote %d events to %s for review; re-run without --%s to broadcast\n", len(manifest.Events), args[0], flagEthRPC)
+				return nil
+			}
+
+			manifestBytes, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("reading manifest: %w", err)
+			}
+
+			var manifest rawEventManifest
+			if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+				return fmt.Errorf("parsing manifest: %w", err)
+			}
+
+			claims, err := claimsFromManifest(manifest)
+			if err != nil {
+				return err
+			}
+			if len(claims) == 0 {
+				return fmt.Errorf("manifest contains no events")
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cosmosAddr := cliCtx.GetFromAddress()
+
+			msg := types.MsgCreateEthereumClaims{
+				EthereumChainID:       manifest.EthereumChainID,
+				BridgeContractAddress: types.NewEthereumAddress(manifest.BridgeContractAddress),
+				Orchestrator:          cosmosAddr,
+				Claims:                claims,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagEthRPC, "", "NOT YET IMPLEMENTED: always fails with an error; scanning this eth node for bridge contract logs is not wired up yet, so events.json must be hand-built for now")
+	cmd.Flags().Uint64(flagFromBlock, 0, "first block to scan (--eth-rpc mode only, not yet implemented)")
+	cmd.Flags().Uint64(flagToBlock, 0, "last block to scan (--eth-rpc mode only, not yet implemented)")
+	return cmd
+}
+
+// claimsFromManifest validates and converts every entry in a manifest into
+// a concrete types.EthereumClaim, one ValidateBasic call per claim so a
+// single malformed event fails the whole batch before anything is signed.
+func claimsFromManifest(manifest rawEventManifest) ([]types.EthereumClaim, error) {
+	claims := make([]types.EthereumClaim, 0, len(manifest.Events))
+	for i, raw := range manifest.Events {
+		var header rawEvent
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, sdkerrors.Wrapf(err, "event %d", i)
+		}
+
+		var claim types.EthereumClaim
+		switch header.Type {
+		case "deposit":
+			var c types.EthereumBridgeDepositClaim
+			if err := json.Unmarshal(raw, &c); err != nil {
+				return nil, sdkerrors.Wrapf(err, "event %d: deposit", i)
+			}
+			claim = c
+		case "withdrawal":
+			var c types.EthereumBridgeWithdrawalBatchClaim
+			if err := json.Unmarshal(raw, &c); err != nil {
+				return nil, sdkerrors.Wrapf(err, "event %d: withdrawal", i)
+			}
+			claim = c
+		case "multisig":
+			var c types.EthereumBridgeMultiSigUpdateClaim
+			if err := json.Unmarshal(raw, &c); err != nil {
+				return nil, sdkerrors.Wrapf(err, "event %d: multisig", i)
+			}
+			claim = c
+		case "bootstrap":
+			var c types.EthereumBridgeBootstrappedClaim
+			if err := json.Unmarshal(raw, &c); err != nil {
+				return nil, sdkerrors.Wrapf(err, "event %d: bootstrap", i)
+			}
+			claim = c
+		default:
+			return nil, fmt.Errorf("event %d: unknown claim type %q", i, header.Type)
+		}
+
+		if err := claim.ValidateBasic(); err != nil {
+			return nil, sdkerrors.Wrapf(err, "event %d", i)
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// scanEthLogsManifest is what from-file calls in --eth-rpc mode. It does not
+// contact the node or scan any logs: it always returns an empty manifest and
+// an error naming the requested block range and endpoint.
+func scanEthLogsManifest(ethRPC string, fromBlock, toBlock uint64) (rawEventManifest, error) {
+	return rawEventManifest{}, fmt.Errorf("eth log scanning for blocks %d-%d against %s is not yet wired up", fromBlock, toBlock, ethRPC)
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagSigningMode         = "signing-mode"
+	flagEthChainIDForDomain = "eth-chain-id"
+	flagEthContractAddress  = "eth-contract-address"
+)
+
+// addSigningModeFlags wires the flags the confirm commands need to pick
+// between legacy raw-checkpoint signing and EIP-712 typed-data signing. The
+// chain's enforced mode (custom/%s/signingMode) always wins; --signing-mode
+// is only consulted to double-check the operator expects that mode, and is
+// rejected outright if it disagrees. The eth-chain-id/eth-contract-address
+// flags only matter in eip712 mode, where they fill in the EIP-712 domain
+// the Solidity verifier expects.
+func addSigningModeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagSigningMode, "legacy", "expected checkpoint signing mode, \"legacy\" or \"eip712\" - checked against, never overrides, the chain's enforced mode")
+	cmd.Flags().Uint64(flagEthChainIDForDomain, 1, "Ethereum chain id for the EIP-712 domain (eip712 mode only)")
+	cmd.Flags().String(flagEthContractAddress, "", "bridge contract address for the EIP-712 domain (eip712 mode only)")
+}
+
+// enforcedSigningMode resolves the SignatureMode a confirm command should
+// sign with: always the chain's enforced mode, queried from
+// custom/%s/signingMode. An explicitly set --signing-mode that disagrees
+// with the chain is a hard error rather than a silently-produced signature
+// no validator peer will accept. It reuses the
+// caller's own cliCtx rather than building a second one.
+func enforcedSigningMode(cmd *cobra.Command, storeKey string, cliCtx context.CLIContext) (types.SignatureMode, error) {
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/signingMode", storeKey), nil)
+	if err != nil {
+		return 0, fmt.Errorf("querying enforced signing mode: %w", err)
+	}
+
+	var enforcedStr string
+	if len(res) > 0 {
+		cliCtx.Codec.MustUnmarshalJSON(res, &enforcedStr)
+	}
+	enforced, err := types.ParseSignatureMode(enforcedStr)
+	if err != nil {
+		return 0, err
+	}
+
+	if !cmd.Flags().Changed(flagSigningMode) {
+		return enforced, nil
+	}
+
+	requested, err := types.ParseSignatureMode(viper.GetString(flagSigningMode))
+	if err != nil {
+		return 0, err
+	}
+	if requested != enforced {
+		return 0, fmt.Errorf("--%s=%s disagrees with the chain's enforced signing mode %q; validator peers will reject a signature in the wrong mode", flagSigningMode, requested, enforced)
+	}
+	return requested, nil
+}
+
+// signValsetCheckpoint signs a valset checkpoint under the chain's enforced
+// signing mode, which --signing-mode may only narrow to (never override).
+func signValsetCheckpoint(cmd *cobra.Command, storeKey string, cliCtx context.CLIContext, valset types.Valset, privateKey *ecdsa.PrivateKey) (types.EthereumSignature, error) {
+	mode, err := enforcedSigningMode(cmd, storeKey, cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	if mode == types.SignatureModeLegacy {
+		return types.NewEthereumSignature(valset.GetCheckpoint(), privateKey)
+	}
+
+	domain := types.EIP712Domain{
+		Name:              "Peggy",
+		Version:           "1",
+		ChainID:           viper.GetUint64(flagEthChainIDForDomain),
+		VerifyingContract: types.NewEthereumAddress(viper.GetString(flagEthContractAddress)),
+	}
+	return types.NewEIP712ValsetSignature(domain, types.Valset712{
+		Nonce:   uint64(valset.Nonce),
+		Powers:  valset.Powers,
+		Members: valset.Members,
+		PeggyID: valset.PeggyID,
+	}, privateKey)
+}
+
+// signBatchCheckpoint signs an outgoing tx batch checkpoint under the
+// chain's enforced signing mode, which --signing-mode may only narrow to.
+func signBatchCheckpoint(cmd *cobra.Command, storeKey string, cliCtx context.CLIContext, batch types.OutgoingTxBatch, checkpoint []byte, privateKey *ecdsa.PrivateKey) (types.EthereumSignature, error) {
+	mode, err := enforcedSigningMode(cmd, storeKey, cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	if mode == types.SignatureModeLegacy {
+		return types.NewEthereumSignature(checkpoint, privateKey)
+	}
+
+	domain := types.EIP712Domain{
+		Name:              "Peggy",
+		Version:           "1",
+		ChainID:           viper.GetUint64(flagEthChainIDForDomain),
+		VerifyingContract: types.NewEthereumAddress(viper.GetString(flagEthContractAddress)),
+	}
+	return types.NewEIP712OutgoingTxBatchSignature(domain, types.OutgoingTxBatch712{
+		Nonce:         uint64(batch.Nonce),
+		BatchTimeout:  batch.BatchTimeout,
+		Transfers:     batch.Transfers,
+		TokenContract: batch.TokenContract,
+	}, privateKey)
+}
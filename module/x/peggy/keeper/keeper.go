@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Keeper holds the store key and wire codec the peggy module's query and
+// msg handlers need to read and write bridge state. This is the minimal
+// slice of the real keeper this change series touches; the rest of its
+// state (attestations, valsets, batches, ...) is handled elsewhere.
+type Keeper struct {
+	cdc      *codec.Codec
+	storeKey sdk.StoreKey
+}
+
+// NewKeeper constructs a Keeper for the given store key, mirroring the
+// standard cosmos-sdk module keeper constructor shape.
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey) Keeper {
+	return Keeper{cdc: cdc, storeKey: storeKey}
+}
@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// migrationBatchTimeout is how long a migration batch's single transfer has
+// to be relayed before it expires, long enough for an operator to notice a
+// stuck migration and re-submit without leaving custody stranded mid-move
+// for an extended period.
+const migrationBatchTimeout = 24 * time.Hour
+
+var (
+	// custodyBalancePrefix namespaces the per-token custody balances the
+	// bridge contract (the safe) currently holds, keyed by token contract
+	// address.
+	custodyBalancePrefix = []byte{0x1}
+	// activeBridgeContractKey stores the Ethereum address deposits and
+	// withdrawals are currently validated against, flipped once a
+	// EthereumBridgeMigrationCompletedClaim attests the new contract
+	// received its migrated custody.
+	activeBridgeContractKey = []byte{0x2}
+)
+
+// CustodyBalance is one line item of the custody-inventory query: the total
+// balance of a single ERC20 the bridge contract currently holds.
+type CustodyBalance struct {
+	TokenContract types.EthereumAddress `json:"token_contract"`
+	Symbol        string                `json:"symbol"`
+	Balance       sdk.Int               `json:"balance"`
+}
+
+// SetCustodyBalance records the bridge contract's tracked balance for a
+// single ERC20, as it's adjusted by observed deposit/withdrawal claims.
+func (k Keeper) SetCustodyBalance(ctx sdk.Context, balance CustodyBalance) {
+	store := ctx.KVStore(k.storeKey)
+	key := append(custodyBalancePrefix, balance.TokenContract.Bytes()...)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(balance))
+}
+
+// GetCustodyInventory walks the keeper's ERC20 tracking state and returns
+// the bridge contract's current balance for every token it has ever
+// tracked, the data `migrate plan` and `custody-inventory` query serve.
+func (k Keeper) GetCustodyInventory(ctx sdk.Context) []CustodyBalance {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, custodyBalancePrefix)
+	defer iterator.Close()
+
+	var inventory []CustodyBalance
+	for ; iterator.Valid(); iterator.Next() {
+		var balance CustodyBalance
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &balance)
+		inventory = append(inventory, balance)
+	}
+	return inventory
+}
+
+// GetActiveBridgeContract returns the Ethereum bridge contract address
+// deposits and withdrawals are currently validated against.
+func (k Keeper) GetActiveBridgeContract(ctx sdk.Context) types.EthereumAddress {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(activeBridgeContractKey)
+	if bz == nil {
+		return types.EthereumAddress{}
+	}
+	var addr types.EthereumAddress
+	k.cdc.MustUnmarshalBinaryBare(bz, &addr)
+	return addr
+}
+
+// SetActiveBridgeContract flips the "active bridge contract" pointer,
+// called once a migration's EthereumBridgeMigrationCompletedClaim has
+// accumulated enough validator attestations.
+func (k Keeper) SetActiveBridgeContract(ctx sdk.Context, addr types.EthereumAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(activeBridgeContractKey, k.cdc.MustMarshalBinaryBare(addr))
+}
+
+// QueryCustodyInventory serves the `custom/peggy/custodyInventory` query
+// path backing both `migrate plan` and `query peggy custody-inventory`.
+func QueryCustodyInventory(ctx sdk.Context, k Keeper) ([]byte, error) {
+	inventory := k.GetCustodyInventory(ctx)
+	return k.cdc.MarshalJSON(inventory)
+}
+
+// HandleMsgCreateMigrationBatch builds an OutgoingTxBatch per token in the
+// custody inventory, each moving the token's full tracked balance from the
+// active bridge contract to msg.NewSafe. The batches it creates are signed
+// by validators through the existing batch-confirm flow like any other
+// outgoing batch, so this only needs to enqueue them.
+func (k Keeper) HandleMsgCreateMigrationBatch(ctx sdk.Context, msg types.MsgCreateMigrationBatch) error {
+	for _, token := range msg.Tokens {
+		if err := k.enqueueMigrationBatch(ctx, token, msg.NewSafe); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueMigrationBatch creates and persists the single-transfer
+// OutgoingTxBatch for one token's migration, moving its full tracked balance
+// to newSafe. It assigns the batch the next outgoing batch nonce and stores
+// it through the same SetOutgoingTxBatch path regular withdrawal batches
+// use, so batch-confirm treats it identically to any other outgoing batch.
+// The token's amount must equal its tracked custody balance, which is
+// zeroed out once its batch is enqueued, so a retried
+// MsgCreateMigrationBatch can't enqueue the same balance twice.
+func (k Keeper) enqueueMigrationBatch(ctx sdk.Context, token types.ERC20Token, newSafe types.EthereumAddress) error {
+	bz := ctx.KVStore(k.storeKey).Get(append(custodyBalancePrefix, token.Contract.Bytes()...))
+	if bz == nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no tracked custody balance for token %s", token.Contract.String())
+	}
+	var tracked CustodyBalance
+	k.cdc.MustUnmarshalBinaryBare(bz, &tracked)
+	if !tracked.Balance.Equal(token.Amount) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "token %s: amount %s does not match tracked custody balance %s", token.Contract.String(), token.Amount.String(), tracked.Balance.String())
+	}
+
+	batch := types.OutgoingTxBatch{
+		Nonce:         k.NextOutgoingBatchNonce(ctx),
+		BatchTimeout:  uint64(ctx.BlockTime().Add(migrationBatchTimeout).Unix()),
+		TokenContract: token.Contract,
+		Transfers: []types.BridgeOutgoingTransferTx{
+			{To: newSafe, Amount: token.Amount},
+		},
+	}
+	k.SetOutgoingTxBatch(ctx, batch)
+	k.SetCustodyBalance(ctx, CustodyBalance{
+		TokenContract: token.Contract,
+		Symbol:        token.Symbol,
+		Balance:       sdk.ZeroInt(),
+	})
+	return nil
+}
+
+// HandleEthereumBridgeMigrationCompletedClaim is invoked once a
+// EthereumBridgeMigrationCompletedClaim has accumulated enough validator
+// attestations, flipping the active bridge contract pointer so subsequent
+// deposits/withdrawals reference the new address.
+func (k Keeper) HandleEthereumBridgeMigrationCompletedClaim(ctx sdk.Context, claim types.EthereumBridgeMigrationCompletedClaim) error {
+	k.SetActiveBridgeContract(ctx, claim.NewSafe)
+	return nil
+}
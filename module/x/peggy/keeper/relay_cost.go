@@ -0,0 +1,177 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// submitBatchSignature is the Solidity signature of the bridge contract's
+// submitBatch call this package ABI-encodes against:
+// submitBatch(uint256 currentValsetNonce, bytes[] sigs, uint256 batchNonce,
+//
+//	address[] destinations, uint256[] amounts, address tokenContract,
+//	uint256 batchTimeout)
+const submitBatchSignature = "submitBatch(uint256,bytes[],uint256,address[],uint256[],address,uint256)"
+
+// RelayCostQueryResponse is the shape served by the
+// custom/peggy/batch/{nonce}/relayCost query: the ABI-encoded submitBatch
+// calldata for a stored batch plus enough context for the CLI to estimate
+// gas and convert the fee into the batch's token.
+type RelayCostQueryResponse struct {
+	BridgeContractAddress types.EthereumAddress `json:"bridge_contract_address"`
+	Calldata              []byte                `json:"calldata"`
+	OraclePriceWei        *big.Int              `json:"oracle_price_wei"`
+}
+
+// submitBatchArguments builds the abi.Arguments describing submitBatchSignature's
+// parameter list, shared by both the encoder and the decoder so they can
+// never drift out of sync with each other.
+func submitBatchArguments() (abi.Arguments, error) {
+	uint256Ty, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	bytesArrTy, err := abi.NewType("bytes[]", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	addressArrTy, err := abi.NewType("address[]", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	uint256ArrTy, err := abi.NewType("uint256[]", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	addressTy, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.Arguments{
+		{Type: uint256Ty},    // currentValsetNonce
+		{Type: bytesArrTy},   // sigs
+		{Type: uint256Ty},    // batchNonce
+		{Type: addressArrTy}, // destinations
+		{Type: uint256ArrTy}, // amounts
+		{Type: addressTy},    // tokenContract
+		{Type: uint256Ty},    // batchTimeout
+	}, nil
+}
+
+// EncodeSubmitBatchCalldata ABI-encodes a submitBatch call for the given
+// valset, its collected confirmations, and the batch itself. It is shared
+// by the batch-relay-cost query handler and the build-eth-relay CLI command
+// so both always agree on the exact bytes a relay tx would submit.
+func EncodeSubmitBatchCalldata(valset types.Valset, confirmations []types.MsgBridgeSignatureSubmission, batch types.OutgoingTxBatch) ([]byte, error) {
+	arguments, err := submitBatchArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := make([][]byte, len(confirmations))
+	for i, c := range confirmations {
+		sigs[i] = []byte(c.EthereumSignature)
+	}
+
+	destinations := make([]ethCommon.Address, len(batch.Transfers))
+	amounts := make([]*big.Int, len(batch.Transfers))
+	for i, t := range batch.Transfers {
+		destinations[i] = ethCommon.HexToAddress(t.To.String())
+		amounts[i] = t.Amount.BigInt()
+	}
+
+	packed, err := arguments.Pack(
+		new(big.Int).SetUint64(uint64(valset.Nonce)),
+		sigs,
+		new(big.Int).SetUint64(uint64(batch.Nonce)),
+		destinations,
+		amounts,
+		ethCommon.HexToAddress(batch.TokenContract.String()),
+		new(big.Int).SetUint64(batch.BatchTimeout),
+	)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "packing submitBatch arguments")
+	}
+
+	selector := ethCrypto.Keccak256([]byte(submitBatchSignature))[:4]
+	return append(selector, packed...), nil
+}
+
+// DecodeSubmitBatchCalldata is the inverse of EncodeSubmitBatchCalldata: it
+// recovers the valset nonce and batch nonce a raw submitBatch calldata blob
+// was built for, so decode-eth-relay can cross-check them against cosmos
+// state.
+func DecodeSubmitBatchCalldata(data []byte) (batchNonce uint64, valsetNonce uint64, err error) {
+	selector := ethCrypto.Keccak256([]byte(submitBatchSignature))[:4]
+	if len(data) < 4 || string(data[:4]) != string(selector) {
+		return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "calldata is not a submitBatch call")
+	}
+
+	arguments, err := submitBatchArguments()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	values, err := arguments.Unpack(data[4:])
+	if err != nil {
+		return 0, 0, sdkerrors.Wrap(err, "unpacking submitBatch arguments")
+	}
+
+	valsetNonceBig, ok := values[0].(*big.Int)
+	if !ok {
+		return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "decoding currentValsetNonce")
+	}
+	batchNonceBig, ok := values[2].(*big.Int)
+	if !ok {
+		return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "decoding batchNonce")
+	}
+
+	return batchNonceBig.Uint64(), valsetNonceBig.Uint64(), nil
+}
+
+// BuildSubmitBatchCalldata loads the last observed valset, the batch for
+// nonce, and its collected confirmations, then ABI-encodes the submitBatch
+// call for them. It backs both the batch-relay-cost query handler and an
+// eventual on-chain fee-market mechanism so they share one encoding.
+func (k Keeper) BuildSubmitBatchCalldata(ctx sdk.Context, nonce types.UInt64Nonce) ([]byte, error) {
+	valset, found := k.GetLastObservedValset(ctx)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no observed valset")
+	}
+	batch, found := k.GetOutgoingTxBatch(ctx, nonce)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no batch for nonce %s", nonce)
+	}
+	confirmations := k.GetBatchConfirmations(ctx, nonce)
+
+	return EncodeSubmitBatchCalldata(valset, confirmations, batch)
+}
+
+// QueryBatchRelayCost serves the custom/peggy/batch/{nonce}/relayCost
+// query: the ABI-encoded submitBatch calldata for the stored batch, the
+// active bridge contract it targets, and the module's tracked oracle price
+// for the batch's token, if any.
+func (k Keeper) QueryBatchRelayCost(ctx sdk.Context, nonce types.UInt64Nonce) ([]byte, error) {
+	calldata, err := k.BuildSubmitBatchCalldata(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	batch, found := k.GetOutgoingTxBatch(ctx, nonce)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no batch for nonce %s", nonce)
+	}
+
+	resp := RelayCostQueryResponse{
+		BridgeContractAddress: k.GetActiveBridgeContract(ctx),
+		Calldata:              calldata,
+		OraclePriceWei:        k.GetOraclePriceWei(ctx, batch.TokenContract),
+	}
+	return k.cdc.MarshalJSON(resp)
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// signatureModeKey stores the types.SignatureMode validators must
+	// currently produce when confirming a valset or batch.
+	signatureModeKey = []byte{0x7}
+	// pendingSignatureModeKey stores a types.SignatureMode queued to take
+	// effect the next time a valset update is observed, so the whole
+	// network switches signing schemes at the same checkpoint instead of
+	// validators disagreeing mid-flight.
+	pendingSignatureModeKey = []byte{0x8}
+)
+
+// GetSignatureMode returns the signing scheme validators must currently use,
+// defaulting to SignatureModeLegacy if none has ever been set.
+func (k Keeper) GetSignatureMode(ctx sdk.Context) types.SignatureMode {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(signatureModeKey)
+	if bz == nil {
+		return types.SignatureModeLegacy
+	}
+	return types.SignatureMode(bz[0])
+}
+
+// SetSignatureMode sets the signing scheme validators must currently use. It
+// takes effect immediately, so callers migrating a live network should
+// prefer ScheduleSignatureMode instead.
+func (k Keeper) SetSignatureMode(ctx sdk.Context, mode types.SignatureMode) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(signatureModeKey, []byte{byte(mode)})
+}
+
+// ScheduleSignatureMode queues mode to become the enforced SignatureMode the
+// next time a valset is observed, the one checkpoint every validator signs
+// against, so the network flips signing schemes atomically instead of some
+// validators confirming under the old scheme and some under the new one.
+func (k Keeper) ScheduleSignatureMode(ctx sdk.Context, mode types.SignatureMode) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(pendingSignatureModeKey, []byte{byte(mode)})
+}
+
+// QuerySignatureMode serves the custom/peggy/signingMode query: the
+// SignatureMode validators are currently required to produce, so the CLI
+// can check --signing-mode against what the chain actually enforces instead
+// of trusting the flag's default blindly.
+func QuerySignatureMode(ctx sdk.Context, k Keeper) ([]byte, error) {
+	return k.cdc.MarshalJSON(k.GetSignatureMode(ctx).String())
+}
+
+// applyPendingSignatureMode promotes a scheduled SignatureMode set by
+// ScheduleSignatureMode to the enforced one and clears the pending entry. It
+// is called whenever a new valset is observed so the switch lands on that
+// checkpoint.
+func (k Keeper) applyPendingSignatureMode(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(pendingSignatureModeKey)
+	if bz == nil {
+		return
+	}
+	store.Set(signatureModeKey, bz)
+	store.Delete(pendingSignatureModeKey)
+}
@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/althea-net/peggy/module/x/peggy/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// lastObservedValsetKey stores the most recently observed Valset,
+	// checkpointed so outgoing batches and migrations can be signed
+	// against it without re-deriving it from the validator set history.
+	lastObservedValsetKey = []byte{0x3}
+	// outgoingBatchPrefix namespaces stored OutgoingTxBatch values by
+	// nonce.
+	outgoingBatchPrefix = []byte{0x4}
+	// batchConfirmationPrefix namespaces the collected validator
+	// confirmations for a batch by nonce.
+	batchConfirmationPrefix = []byte{0x5}
+	// oraclePricePrefix namespaces the module's tracked wei-per-token
+	// price by token contract address.
+	oraclePricePrefix = []byte{0x6}
+	// lastOutgoingBatchNonceKey stores the most recently assigned outgoing
+	// batch nonce, so new batches (migrations included) get the next one
+	// instead of colliding with an existing one.
+	lastOutgoingBatchNonceKey = []byte{0x9}
+)
+
+// nonceKey turns a batch nonce into a fixed-width, order-preserving store
+// key suffix.
+func nonceKey(nonce types.UInt64Nonce) []byte {
+	return sdk.Uint64ToBigEndian(uint64(nonce))
+}
+
+// GetLastObservedValset returns the most recently observed Valset, if one
+// has ever been recorded.
+func (k Keeper) GetLastObservedValset(ctx sdk.Context) (types.Valset, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(lastObservedValsetKey)
+	if bz == nil {
+		return types.Valset{}, false
+	}
+	var valset types.Valset
+	k.cdc.MustUnmarshalBinaryBare(bz, &valset)
+	return valset, true
+}
+
+// SetLastObservedValset records the most recently observed Valset and
+// promotes any SignatureMode queued by ScheduleSignatureMode, since a newly
+// observed valset is the one checkpoint every validator necessarily signs
+// against, making it the only safe point to flip signing schemes network-wide.
+func (k Keeper) SetLastObservedValset(ctx sdk.Context, valset types.Valset) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(lastObservedValsetKey, k.cdc.MustMarshalBinaryBare(valset))
+	k.applyPendingSignatureMode(ctx)
+}
+
+// GetOutgoingTxBatch returns the stored batch for a nonce, if one exists.
+func (k Keeper) GetOutgoingTxBatch(ctx sdk.Context, nonce types.UInt64Nonce) (types.OutgoingTxBatch, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(outgoingBatchPrefix, nonceKey(nonce)...))
+	if bz == nil {
+		return types.OutgoingTxBatch{}, false
+	}
+	var batch types.OutgoingTxBatch
+	k.cdc.MustUnmarshalBinaryBare(bz, &batch)
+	return batch, true
+}
+
+// SetOutgoingTxBatch stores a batch, keyed by its own nonce.
+func (k Keeper) SetOutgoingTxBatch(ctx sdk.Context, batch types.OutgoingTxBatch) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(outgoingBatchPrefix, nonceKey(batch.Nonce)...), k.cdc.MustMarshalBinaryBare(batch))
+}
+
+// NextOutgoingBatchNonce assigns and persists the next outgoing batch nonce,
+// so every batch any caller creates - regular withdrawals or a migration -
+// gets a unique, strictly increasing one.
+func (k Keeper) NextOutgoingBatchNonce(ctx sdk.Context) types.UInt64Nonce {
+	store := ctx.KVStore(k.storeKey)
+	var last uint64
+	if bz := store.Get(lastOutgoingBatchNonceKey); bz != nil {
+		last = sdk.BigEndianToUint64(bz)
+	}
+	next := last + 1
+	store.Set(lastOutgoingBatchNonceKey, sdk.Uint64ToBigEndian(next))
+	return types.UInt64Nonce(next)
+}
+
+// GetBatchConfirmations returns the validator confirmations collected so
+// far for a batch nonce.
+func (k Keeper) GetBatchConfirmations(ctx sdk.Context, nonce types.UInt64Nonce) []types.MsgBridgeSignatureSubmission {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(batchConfirmationPrefix, nonceKey(nonce)...))
+	if bz == nil {
+		return nil
+	}
+	var confirmations []types.MsgBridgeSignatureSubmission
+	k.cdc.MustUnmarshalBinaryBare(bz, &confirmations)
+	return confirmations
+}
+
+// AddBatchConfirmation appends one more validator confirmation to a batch's
+// collected set.
+func (k Keeper) AddBatchConfirmation(ctx sdk.Context, nonce types.UInt64Nonce, confirmation types.MsgBridgeSignatureSubmission) {
+	confirmations := append(k.GetBatchConfirmations(ctx, nonce), confirmation)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(batchConfirmationPrefix, nonceKey(nonce)...), k.cdc.MustMarshalBinaryBare(confirmations))
+}
+
+// GetOraclePriceWei returns the module's tracked wei-per-token price for an
+// ERC20, or nil if none has been set, so callers fall back to a
+// caller-supplied price.
+func (k Keeper) GetOraclePriceWei(ctx sdk.Context, token types.EthereumAddress) *big.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(append(oraclePricePrefix, token.Bytes()...))
+	if bz == nil {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
+
+// SetOraclePriceWei records the module's tracked wei-per-token price for an
+// ERC20.
+func (k Keeper) SetOraclePriceWei(ctx sdk.Context, token types.EthereumAddress, priceWei *big.Int) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(oraclePricePrefix, token.Bytes()...), priceWei.Bytes())
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// EthereumBridgeMigrationCompletedClaim is attested by orchestrators once
+// the newly deployed bridge contract has received the custody batches
+// created by MsgCreateMigrationBatch. Once enough validators attest it,
+// the keeper flips its "active bridge contract" pointer to NewSafe so all
+// subsequent deposits/withdrawals reference the new address.
+type EthereumBridgeMigrationCompletedClaim struct {
+	Nonce   UInt64Nonce     `json:"nonce"`
+	NewSafe EthereumAddress `json:"new_safe"`
+}
+
+// ValidateBasic performs stateless validation of the claim.
+func (e EthereumBridgeMigrationCompletedClaim) ValidateBasic() error {
+	if e.NewSafe == (EthereumAddress{}) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new safe address is required")
+	}
+	return nil
+}
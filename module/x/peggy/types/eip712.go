@@ -0,0 +1,207 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrInvalidSignatureMode is returned when --signing-mode names anything
+// other than "legacy" or "eip712".
+var ErrInvalidSignatureMode = errors.New("invalid signing mode, must be \"legacy\" or \"eip712\"")
+
+// EIP712Domain pins the chain-specific parameters a validator signs into its
+// typed-data signature, mirroring the bridge contract's own domain
+// separator so the Solidity-side verifier recovers the same signer.
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract EthereumAddress
+	Salt              []byte
+}
+
+// Valset712 is the typed-data representation of a Valset checkpoint,
+// `Valset{nonce, powers[], members[], peggyId}`, as signed by EIP-712
+// capable wallets for a multisig set update.
+type Valset712 struct {
+	Nonce   uint64
+	Powers  []uint64
+	Members []EthereumAddress
+	PeggyID []byte
+}
+
+// OutgoingTxBatch712 is the typed-data representation of an OutgoingTxBatch
+// checkpoint, `OutgoingTxBatch{nonce, batchTimeout, transfers[], tokenContract}`.
+type OutgoingTxBatch712 struct {
+	Nonce         uint64
+	BatchTimeout  uint64
+	Transfers     []BridgeOutgoingTransferTx
+	TokenContract EthereumAddress
+}
+
+// valsetEIP712TypedData builds the apitypes.TypedData for a Valset712 message
+// under the given domain, matching the schema the Solidity-side EIP-712
+// verifier expects.
+func valsetEIP712TypedData(domain EIP712Domain, msg Valset712) apitypes.TypedData {
+	members := make([]interface{}, len(msg.Members))
+	for i, m := range msg.Members {
+		members[i] = m.String()
+	}
+	powers := make([]interface{}, len(msg.Powers))
+	for i, p := range msg.Powers {
+		powers[i] = p
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Valset": []apitypes.Type{
+				{Name: "nonce", Type: "uint256"},
+				{Name: "powers", Type: "uint256[]"},
+				{Name: "members", Type: "address[]"},
+				{Name: "peggyId", Type: "bytes32"},
+			},
+		},
+		PrimaryType: "Valset",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           apitypes.NewBigIntFromUint64(domain.ChainID),
+			VerifyingContract: domain.VerifyingContract.String(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"nonce":   msg.Nonce,
+			"powers":  powers,
+			"members": members,
+			"peggyId": msg.PeggyID,
+		},
+	}
+}
+
+// outgoingTxBatchEIP712TypedData builds the apitypes.TypedData for an
+// OutgoingTxBatch712 message under the given domain.
+func outgoingTxBatchEIP712TypedData(domain EIP712Domain, msg OutgoingTxBatch712) apitypes.TypedData {
+	transfers := make([]interface{}, len(msg.Transfers))
+	for i, t := range msg.Transfers {
+		// apitypes encodes struct-typed array items only from field maps.
+		transfers[i] = map[string]interface{}{
+			"to":     t.To.String(),
+			"amount": t.Amount.BigInt(),
+		}
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"OutgoingTxBatch": []apitypes.Type{
+				{Name: "nonce", Type: "uint256"},
+				{Name: "batchTimeout", Type: "uint256"},
+				{Name: "transfers", Type: "Transfer[]"},
+				{Name: "tokenContract", Type: "address"},
+			},
+			"Transfer": []apitypes.Type{
+				{Name: "to", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+		},
+		PrimaryType: "OutgoingTxBatch",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           apitypes.NewBigIntFromUint64(domain.ChainID),
+			VerifyingContract: domain.VerifyingContract.String(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"nonce":         msg.Nonce,
+			"batchTimeout":  msg.BatchTimeout,
+			"transfers":     transfers,
+			"tokenContract": msg.TokenContract.String(),
+		},
+	}
+}
+
+// eip712Digest computes `\x19\x01 || domainSeparator || hashStruct(message)`,
+// the digest an EIP-712 compliant wallet or the Solidity verifier signs.
+func eip712Digest(typedData apitypes.TypedData) ([]byte, error) {
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	rawData := append([]byte{0x19, 0x01}, domainSeparator...)
+	rawData = append(rawData, messageHash...)
+	return ethCrypto.Keccak256(rawData), nil
+}
+
+// NewEIP712ValsetSignature signs a Valset checkpoint as EIP-712 typed data
+// instead of the raw 32-byte checkpoint hash, so hardware and browser
+// wallets can display the signed fields instead of an opaque digest.
+func NewEIP712ValsetSignature(domain EIP712Domain, msg Valset712, privateKey *ecdsa.PrivateKey) (EthereumSignature, error) {
+	digest, err := eip712Digest(valsetEIP712TypedData(domain, msg))
+	if err != nil {
+		return nil, err
+	}
+	return NewEthereumSignature(digest, privateKey)
+}
+
+// NewEIP712OutgoingTxBatchSignature signs an OutgoingTxBatch checkpoint as
+// EIP-712 typed data instead of the raw 32-byte checkpoint hash.
+func NewEIP712OutgoingTxBatchSignature(domain EIP712Domain, msg OutgoingTxBatch712, privateKey *ecdsa.PrivateKey) (EthereumSignature, error) {
+	digest, err := eip712Digest(outgoingTxBatchEIP712TypedData(domain, msg))
+	if err != nil {
+		return nil, err
+	}
+	return NewEthereumSignature(digest, privateKey)
+}
+
+// SignatureMode governs which checkpoint form validators must produce when
+// confirming a valset or batch, stored as a keeper param so the whole
+// network moves between the two atomically via a valset update.
+type SignatureMode byte
+
+const (
+	// SignatureModeLegacy signs the raw 32-byte checkpoint hash directly,
+	// the original scheme with no Solidity-side typed-data verifier.
+	SignatureModeLegacy SignatureMode = iota
+	// SignatureModeEIP712 signs the EIP-712 typed-data digest instead,
+	// required once the bridge contract's verifier understands it.
+	SignatureModeEIP712
+)
+
+func (m SignatureMode) String() string {
+	switch m {
+	case SignatureModeEIP712:
+		return "eip712"
+	default:
+		return "legacy"
+	}
+}
+
+// ParseSignatureMode parses the --signing-mode flag value into a SignatureMode.
+func ParseSignatureMode(s string) (SignatureMode, error) {
+	switch s {
+	case "", "legacy":
+		return SignatureModeLegacy, nil
+	case "eip712":
+		return SignatureModeEIP712, nil
+	default:
+		return 0, ErrInvalidSignatureMode
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// TypeMsgCreateMigrationBatch is the sdk.Msg type string for MsgCreateMigrationBatch.
+const TypeMsgCreateMigrationBatch = "create_migration_batch"
+
+// MsgCreateMigrationBatch asks validators to sign an OutgoingTxBatch per
+// token, each moving the token's full balance from the current bridge
+// contract (the safe) to NewSafe. The resulting batches are signed through
+// the existing batch-confirm flow, the same as any other outgoing batch.
+type MsgCreateMigrationBatch struct {
+	Orchestrator sdk.AccAddress  `json:"orchestrator"`
+	NewSafe      EthereumAddress `json:"new_safe"`
+	Tokens       []ERC20Token    `json:"tokens"`
+}
+
+// Route returns the peggy module's route name.
+func (msg MsgCreateMigrationBatch) Route() string { return RouterKey }
+
+// Type returns TypeMsgCreateMigrationBatch.
+func (msg MsgCreateMigrationBatch) Type() string { return TypeMsgCreateMigrationBatch }
+
+// ValidateBasic performs stateless validation of the msg.
+func (msg MsgCreateMigrationBatch) ValidateBasic() error {
+	if msg.Orchestrator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing orchestrator address")
+	}
+	if msg.NewSafe == (EthereumAddress{}) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new safe address is required")
+	}
+	if len(msg.Tokens) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "at least one token is required")
+	}
+	return nil
+}
+
+// GetSignBytes returns the canonical byte representation to sign.
+func (msg MsgCreateMigrationBatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required.
+func (msg MsgCreateMigrationBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Orchestrator}
+}